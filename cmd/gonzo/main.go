@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/Mu-L/gonzo/internal/config"
+	gonzolog "github.com/Mu-L/gonzo/internal/log"
+	"github.com/Mu-L/gonzo/internal/logentry"
+	"github.com/Mu-L/gonzo/internal/loki"
+	"github.com/Mu-L/gonzo/internal/sinks"
+	"github.com/Mu-L/gonzo/internal/stopwords"
 )
 
 // Build variables - set by ldflags during build
@@ -26,83 +37,160 @@ func GetVersionInfo() (string, string) {
 
 // Config struct for application configuration
 type Config struct {
-	MemorySize           int           `mapstructure:"memory-size"`
-	UpdateInterval       time.Duration `mapstructure:"update-interval"`
-	LogBuffer            int           `mapstructure:"log-buffer"`
-	TestMode             bool          `mapstructure:"test-mode"`
-	ConfigFile           string        `mapstructure:"config"`
-	AIModel              string        `mapstructure:"ai-model"`
-	Files                []string      `mapstructure:"files"`
-	Follow               bool          `mapstructure:"follow"`
-	OTLPEnabled          bool          `mapstructure:"otlp-enabled"`
-	OTLPGRPCPort         int           `mapstructure:"otlp-grpc-port"`
-	OTLPHTTPPort         int           `mapstructure:"otlp-http-port"`
-	VmlogsURL            string        `mapstructure:"vmlogs-url"`
-	VmlogsUser           string        `mapstructure:"vmlogs-user"`
-	VmlogsPassword       string        `mapstructure:"vmlogs-password"`
-	VmlogsQuery          string        `mapstructure:"vmlogs-query"`
-	Skin                 string        `mapstructure:"skin"`
-	StopWords            []string      `mapstructure:"stop-words"`
-	Format               string        `mapstructure:"format"`
-	DisableVersionCheck  bool          `mapstructure:"disable-version-check"`
-	ReverseScrollWheel   bool          `mapstructure:"reverse-scroll-wheel"`
+	MemorySize          int           `mapstructure:"memory-size"`
+	UpdateInterval      time.Duration `mapstructure:"update-interval"`
+	LogBuffer           int           `mapstructure:"log-buffer"`
+	TestMode            bool          `mapstructure:"test-mode"`
+	ConfigFile          string        `mapstructure:"config"`
+	AIModel             string        `mapstructure:"ai-model"`
+	Files               []string      `mapstructure:"files"`
+	Follow              bool          `mapstructure:"follow"`
+	OTLPEnabled         bool          `mapstructure:"otlp-enabled"`
+	OTLPGRPCPort        int           `mapstructure:"otlp-grpc-port"`
+	OTLPHTTPPort        int           `mapstructure:"otlp-http-port"`
+	VmlogsURL           string        `mapstructure:"vmlogs-url"`
+	VmlogsUser          string        `mapstructure:"vmlogs-user"`
+	VmlogsPassword      string        `mapstructure:"vmlogs-password"`
+	VmlogsQuery         string        `mapstructure:"vmlogs-query"`
+	LokiURL             string        `mapstructure:"loki-url"`
+	LokiUser            string        `mapstructure:"loki-user"`
+	LokiPassword        string        `mapstructure:"loki-password"`
+	LokiToken           string        `mapstructure:"loki-token"`
+	LokiQuery           string        `mapstructure:"loki-query"`
+	LokiTail            bool          `mapstructure:"loki-tail"`
+	Skin                string        `mapstructure:"skin"`
+	StopWords           []string      `mapstructure:"stop-words"`
+	Format              string        `mapstructure:"format"`
+	DisableVersionCheck bool          `mapstructure:"disable-version-check"`
+	ReverseScrollWheel  bool          `mapstructure:"reverse-scroll-wheel"`
+	LogLevel            string        `mapstructure:"log-level"`
+	LogFormat           string        `mapstructure:"log-format"`
+	LogFile             string        `mapstructure:"log-file"`
+	Sinks               []string      `mapstructure:"sink"`
+	SinkConfigs         []SinkConfig  `mapstructure:"sinks"`
+	Profile             string        `mapstructure:"profile"`
 }
 
-var (
-	cfg     Config
-	cfgFile string
-	rootCmd = &cobra.Command{
+// SinkConfig is one entry of the `sinks:` config file section. A `--sink`
+// flag value is a shorthand for one with only Type and Target set, in
+// the form "type=target" (e.g. "file=/var/log/gonzo-tap.json").
+type SinkConfig struct {
+	Type      string `mapstructure:"type"`
+	Target    string `mapstructure:"target"`
+	QueueSize int    `mapstructure:"queue-size"`
+}
+
+// Runnable is a subsystem that runs alongside the TUI for the lifetime of
+// the process. In this tree the Loki client, the sink dispatcher and the
+// stop-words reload subscriber implement it; the OTLP listener, vmlogs
+// client, file tailers and the TUI do not. Start blocks until ctx is
+// cancelled or the subsystem finishes or fails on its own (lokiRunnable
+// returns once its backfill is done when --loki-tail is off); Stop gives
+// it a chance to flush/drain before the process exits.
+type Runnable interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// App holds everything that used to live in package-level globals: the
+// resolved configuration, the viper instance it was decoded from, the
+// cobra command tree, and the reload broadcaster. runApp constructs one
+// App per process and threads it through explicitly instead of reaching
+// for globals, which is what makes it possible to unit test the CLI
+// wiring and to run more than one App in a test process.
+type App struct {
+	cfg          Config
+	cfgFile      string
+	viper        *viper.Viper
+	configReload config.Broadcaster
+
+	// reloadMu serializes reloadConfig, which is reachable concurrently
+	// from the SIGHUP handler goroutine and viper's own fsnotify-watcher
+	// goroutine - without it, a SIGHUP arriving close to a config-file
+	// save can unmarshal into app.cfg and re-init the logger from two
+	// goroutines at once.
+	reloadMu sync.Mutex
+
+	// stopWords is the live --stop-words set the analyzer consults. It's
+	// seeded from cfg in buildRunnables and kept in sync with it by
+	// stopWordsRunnable, the concrete example of a subsystem that swaps
+	// its state on a configReload notification instead of re-reading cfg
+	// directly.
+	stopWords *stopwords.Set
+
+	rootCmd *cobra.Command
+}
+
+// NewApp builds the cobra command tree and binds its flags to a
+// dedicated viper instance, but does not read any configuration yet -
+// that happens in initConfig, once cobra has parsed flags.
+func NewApp() *App {
+	app := &App{viper: viper.New()}
+
+	app.rootCmd = &cobra.Command{
 		Use:   "gonzo",
 		Short: "Real-time log analysis terminal UI",
 		Long: `Gonzo - A powerful, real-time log analysis terminal UI inspired by k9s.
-		
+
 Analyze log streams with beautiful charts, AI-powered insights, and advanced filtering - all from your terminal.
 
 Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON, logfmt, and plain text logs.`,
 		Example: `  # Analyze logs from stdin
   cat application.log | gonzo
-  
+
   # Read logs directly from files
   gonzo -f application.log -f error.log
-  
+
   # Follow log files in real-time (like tail -f)
   gonzo -f /var/log/app.log --follow
-  
+
   # Use glob patterns to read multiple files
   gonzo -f "/var/log/*.log" --follow
-  
-  # Stream logs from kubectl  
+
+  # Stream logs from kubectl
   kubectl logs -f deployment/my-app | gonzo
-  
+
   # With custom settings
   gonzo -f logs.json --update-interval=2s --log-buffer=2000
-  
+
   # With AI analysis (auto-selects best model)
   export OPENAI_API_KEY=sk-your-key-here
   gonzo -f application.log --ai-model="gpt-4"
-  
+
   # With local AI server (auto-selects available model)
   export OPENAI_API_BASE="http://127.0.0.1:1234/v1"
   export OPENAI_API_KEY="local-key"
   gonzo -f logs.json --follow
-  
+
   # With OTLP listener (both gRPC and HTTP)
   gonzo --otlp-enabled
-  
+
   # With custom ports
   gonzo --otlp-enabled --otlp-grpc-port=4317 --otlp-http-port=4318
-  
+
   # Stream logs from Victoria Logs
   gonzo --vmlogs-url="http://localhost:9428" --vmlogs-query="*"
-  
+
   # With authentication and custom query
   gonzo --vmlogs-url="https://vmlogs.example.com" --vmlogs-user="myuser" --vmlogs-password="mypass" --vmlogs-query='level:error'
-  
+
   # Using environment variables for authentication
   export GONZO_VMLOGS_USER="myuser"
-  export GONZO_VMLOGS_PASSWORD="mypass"  
+  export GONZO_VMLOGS_PASSWORD="mypass"
   gonzo --vmlogs-url="https://vmlogs.example.com" --vmlogs-query='service:"myapp"'
 
+  # Stream logs from Loki
+  gonzo --loki-url="http://localhost:3100" --loki-query='{app="myapp"}'
+
+  # Follow new Loki entries in real-time
+  gonzo --loki-url="http://localhost:3100" --loki-query='{app="myapp"}' --loki-tail
+
+  # Tee ingested logs to a file while still viewing them in the TUI
+  gonzo -f app.log --sink="file=/var/log/gonzo-tap.json"
+
+  # Launch with a named settings profile from the config file
+  gonzo --profile=k8s-prod
+
   # Using a custom color scheme/skin
   gonzo --skin=dracula
 
@@ -116,10 +204,10 @@ Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON,
   # Use built-in formats explicitly
   gonzo --format=json -f structured.log
   gonzo --format=text -f plain.log`,
-		RunE: runApp,
+		RunE: app.runApp,
 	}
 
-	versionCmd = &cobra.Command{
+	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print version information",
 		Long:  `Print detailed version information about Gonzo.`,
@@ -131,13 +219,29 @@ Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON,
 			fmt.Printf("  Go version: %s\n", goVersion)
 		},
 	}
-)
+	app.rootCmd.AddCommand(versionCmd)
+
+	// Bound to this App's own rootCmd rather than registered with
+	// cobra.OnInitialize, which maintains one package-level initializer
+	// list shared by every App in the process: constructing a second App
+	// would otherwise leave the first one's initConfig (and the SIGHUP
+	// watcher it starts) permanently registered, so every later Execute
+	// call re-runs every App's init logic.
+	app.rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		app.initConfig()
+		return nil
+	}
+	app.registerFlags()
+
+	return app
+}
 
-func init() {
-	cobra.OnInitialize(initConfig)
+func (app *App) registerFlags() {
+	rootCmd := app.rootCmd
+	v := app.viper
 
 	// Root command flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/gonzo/config.yml)")
+	rootCmd.PersistentFlags().StringVar(&app.cfgFile, "config", "", "config file (default is $HOME/.config/gonzo/config.yml)")
 	rootCmd.Flags().IntP("memory-size", "m", 10000, "Maximum number of entries to keep in memory")
 	rootCmd.Flags().DurationP("update-interval", "u", 1*time.Second, "Dashboard update interval")
 	rootCmd.Flags().IntP("log-buffer", "b", 1000, "Maximum log buffer size")
@@ -153,72 +257,497 @@ func init() {
 	rootCmd.Flags().String("vmlogs-user", "", "Victoria Logs basic auth username (can also use GONZO_VMLOGS_USER env var)")
 	rootCmd.Flags().String("vmlogs-password", "", "Victoria Logs basic auth password (can also use GONZO_VMLOGS_PASSWORD env var)")
 	rootCmd.Flags().String("vmlogs-query", "*", "Victoria Logs query (LogsQL) to use for streaming (default: '*' for all logs)")
+	rootCmd.Flags().String("loki-url", "", "Loki URL endpoint for streaming logs (e.g., http://localhost:3100)")
+	rootCmd.Flags().String("loki-user", "", "Loki basic auth username (can also use GONZO_LOKI_USER env var)")
+	rootCmd.Flags().String("loki-password", "", "Loki basic auth password (can also use GONZO_LOKI_PASSWORD env var)")
+	rootCmd.Flags().String("loki-token", "", "Loki bearer token, used instead of basic auth (can also use GONZO_LOKI_TOKEN env var)")
+	rootCmd.Flags().String("loki-query", "{}", "Loki query (LogQL) to use for streaming (default: '{}' for all logs)")
+	rootCmd.Flags().Bool("loki-tail", false, "Follow new Loki entries in real-time via the tail websocket endpoint")
 	rootCmd.Flags().StringP("skin", "s", "default", "Color scheme/skin to use (default, or name of a skin file in ~/.config/gonzo/skins/)")
 	rootCmd.Flags().StringSlice("stop-words", []string{}, "Additional stop words to filter out from analysis (adds to built-in list)")
 	rootCmd.Flags().String("format", "", "Log format to use (auto-detect if not specified). Can be: otlp, json, text, or a custom format name from ~/.config/gonzo/formats/")
 	rootCmd.Flags().Bool("disable-version-check", false, "Disable automatic version checking on startup")
 	rootCmd.Flags().Bool("reverse-scroll-wheel", false, "Reverse scroll wheel direction (natural scrolling)")
+	rootCmd.Flags().String("log-level", "info", "Log level for diagnostics: debug, info, warn, or error")
+	rootCmd.Flags().String("log-format", "auto", "Log output format: auto, tty (colorized), or json (default: auto-detect from stdout)")
+	rootCmd.Flags().String("log-file", "", "Additionally write logs to this file (one record per line, in the same format as --log-format resolves to)")
+	rootCmd.Flags().StringSlice("sink", []string{}, "Forward ingested logs to a destination as 'type=target' (can specify multiple). Types: file, otlp-grpc, otlp-http, loki-push, vmlogs-push")
+	rootCmd.Flags().String("profile", "", "Named settings profile to apply from the 'profiles:' section of the config file (can also use GONZO_PROFILE env var)")
 
 	// Bind flags to viper
-	viper.BindPFlag("memory-size", rootCmd.Flags().Lookup("memory-size"))
-	viper.BindPFlag("update-interval", rootCmd.Flags().Lookup("update-interval"))
-	viper.BindPFlag("log-buffer", rootCmd.Flags().Lookup("log-buffer"))
-	viper.BindPFlag("test-mode", rootCmd.Flags().Lookup("test-mode"))
-	viper.BindPFlag("ai-model", rootCmd.Flags().Lookup("ai-model"))
-	viper.BindPFlag("files", rootCmd.Flags().Lookup("file"))
-	viper.BindPFlag("follow", rootCmd.Flags().Lookup("follow"))
-	viper.BindPFlag("otlp-enabled", rootCmd.Flags().Lookup("otlp-enabled"))
-	viper.BindPFlag("otlp-grpc-port", rootCmd.Flags().Lookup("otlp-grpc-port"))
-	viper.BindPFlag("otlp-http-port", rootCmd.Flags().Lookup("otlp-http-port"))
-	viper.BindPFlag("vmlogs-url", rootCmd.Flags().Lookup("vmlogs-url"))
-	viper.BindPFlag("vmlogs-user", rootCmd.Flags().Lookup("vmlogs-user"))
-	viper.BindPFlag("vmlogs-password", rootCmd.Flags().Lookup("vmlogs-password"))
-	viper.BindPFlag("vmlogs-query", rootCmd.Flags().Lookup("vmlogs-query"))
-	viper.BindPFlag("skin", rootCmd.Flags().Lookup("skin"))
-	viper.BindPFlag("stop-words", rootCmd.Flags().Lookup("stop-words"))
-	viper.BindPFlag("format", rootCmd.Flags().Lookup("format"))
-	viper.BindPFlag("disable-version-check", rootCmd.Flags().Lookup("disable-version-check"))
-	viper.BindPFlag("reverse-scroll-wheel", rootCmd.Flags().Lookup("reverse-scroll-wheel"))
-
-	// Add version command
-	rootCmd.AddCommand(versionCmd)
-}
-
-func initConfig() {
-	if cfgFile != "" {
+	v.BindPFlag("memory-size", rootCmd.Flags().Lookup("memory-size"))
+	v.BindPFlag("update-interval", rootCmd.Flags().Lookup("update-interval"))
+	v.BindPFlag("log-buffer", rootCmd.Flags().Lookup("log-buffer"))
+	v.BindPFlag("test-mode", rootCmd.Flags().Lookup("test-mode"))
+	v.BindPFlag("ai-model", rootCmd.Flags().Lookup("ai-model"))
+	v.BindPFlag("files", rootCmd.Flags().Lookup("file"))
+	v.BindPFlag("follow", rootCmd.Flags().Lookup("follow"))
+	v.BindPFlag("otlp-enabled", rootCmd.Flags().Lookup("otlp-enabled"))
+	v.BindPFlag("otlp-grpc-port", rootCmd.Flags().Lookup("otlp-grpc-port"))
+	v.BindPFlag("otlp-http-port", rootCmd.Flags().Lookup("otlp-http-port"))
+	v.BindPFlag("vmlogs-url", rootCmd.Flags().Lookup("vmlogs-url"))
+	v.BindPFlag("vmlogs-user", rootCmd.Flags().Lookup("vmlogs-user"))
+	v.BindPFlag("vmlogs-password", rootCmd.Flags().Lookup("vmlogs-password"))
+	v.BindPFlag("vmlogs-query", rootCmd.Flags().Lookup("vmlogs-query"))
+	v.BindPFlag("loki-url", rootCmd.Flags().Lookup("loki-url"))
+	v.BindPFlag("loki-user", rootCmd.Flags().Lookup("loki-user"))
+	v.BindPFlag("loki-password", rootCmd.Flags().Lookup("loki-password"))
+	v.BindPFlag("loki-token", rootCmd.Flags().Lookup("loki-token"))
+	v.BindPFlag("loki-query", rootCmd.Flags().Lookup("loki-query"))
+	v.BindPFlag("loki-tail", rootCmd.Flags().Lookup("loki-tail"))
+	v.BindPFlag("skin", rootCmd.Flags().Lookup("skin"))
+	v.BindPFlag("stop-words", rootCmd.Flags().Lookup("stop-words"))
+	v.BindPFlag("format", rootCmd.Flags().Lookup("format"))
+	v.BindPFlag("disable-version-check", rootCmd.Flags().Lookup("disable-version-check"))
+	v.BindPFlag("reverse-scroll-wheel", rootCmd.Flags().Lookup("reverse-scroll-wheel"))
+	v.BindPFlag("log-level", rootCmd.Flags().Lookup("log-level"))
+	v.BindPFlag("log-format", rootCmd.Flags().Lookup("log-format"))
+	v.BindPFlag("log-file", rootCmd.Flags().Lookup("log-file"))
+	v.BindPFlag("sink", rootCmd.Flags().Lookup("sink"))
+	v.BindPFlag("profile", rootCmd.Flags().Lookup("profile"))
+}
+
+func (app *App) initConfig() {
+	v := app.viper
+
+	if app.cfgFile != "" {
 		// Use config file from the flag
-		viper.SetConfigFile(cfgFile)
+		v.SetConfigFile(app.cfgFile)
 	} else {
 		// Find XDG config directory
 		home, err := os.UserHomeDir()
 		if err != nil {
-			log.Printf("Error finding home directory: %v", err)
+			gonzolog.Default().Error("Error finding home directory", "error", err)
 		} else {
 			configDir := home + "/.config/gonzo"
-			viper.AddConfigPath(configDir)
-			viper.SetConfigType("yaml")
-			viper.SetConfigName("config")
+			v.AddConfigPath(configDir)
+			v.SetConfigType("yaml")
+			v.SetConfigName("config")
 		}
 	}
 
 	// Support environment variables
-	viper.SetEnvPrefix("GONZO")
-	viper.AutomaticEnv()
-	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.SetEnvPrefix("GONZO")
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 
 	// Read config file if it exists
-	if err := viper.ReadInConfig(); err == nil {
-		log.Printf("Using config file: %s", viper.ConfigFileUsed())
+	if err := v.ReadInConfig(); err == nil {
+		gonzolog.Default().Info("Using config file", "path", v.ConfigFileUsed())
+	}
+
+	if err := app.applyProfile(); err != nil {
+		gonzolog.Fatal("Unable to apply profile", "error", err)
 	}
 
 	// Unmarshal config
-	if err := viper.Unmarshal(&cfg); err != nil {
-		log.Fatalf("Unable to decode config: %v", err)
+	if err := v.Unmarshal(&app.cfg); err != nil {
+		gonzolog.Fatal("Unable to decode config", "error", err)
+	}
+
+	if err := app.initLogger(); err != nil {
+		gonzolog.Fatal("Unable to initialize logger", "error", err)
+	}
+
+	// Re-read and re-unmarshal on config file changes, and publish a
+	// reload event so subscribed subsystems can swap their state without
+	// restarting the TUI.
+	v.OnConfigChange(func(e fsnotify.Event) {
+		gonzolog.Default().Info("Config file changed", "path", e.Name)
+		app.reloadConfig()
+	})
+	v.WatchConfig()
+
+	// A SIGHUP forces a re-read even when the config file itself didn't
+	// change, e.g. after editing a skin or format file it references.
+	go app.watchSIGHUP()
+}
+
+// initLogger configures the package-level logger from the resolved
+// --log-level/--log-format/--log-file settings. It's called once on
+// startup and again after every reload so log verbosity can be tuned live.
+func (app *App) initLogger() error {
+	level, err := gonzolog.ParseLevel(app.cfg.LogLevel)
+	if err != nil {
+		return fmt.Errorf("invalid --log-level %q: %w", app.cfg.LogLevel, err)
+	}
+
+	_, err = gonzolog.Init(gonzolog.Options{
+		Level:  level,
+		Format: gonzolog.Format(app.cfg.LogFormat),
+		File:   app.cfg.LogFile,
+	})
+	return err
+}
+
+// reloadConfig re-unmarshals viper's current state into app.cfg and
+// publishes a reload event, all under reloadMu. Reload subscribers that
+// read app.cfg (stopWordsRunnable) take reloadMu while they read it, so
+// a later reload never writes app.cfg under them.
+func (app *App) reloadConfig() {
+	app.reloadMu.Lock()
+	defer app.reloadMu.Unlock()
+
+	if err := app.applyProfile(); err != nil {
+		gonzolog.Default().Error("Unable to apply profile on reload", "error", err)
+		return
+	}
+	if err := app.viper.Unmarshal(&app.cfg); err != nil {
+		gonzolog.Default().Error("Unable to decode config on reload", "error", err)
+		return
+	}
+	if err := app.initLogger(); err != nil {
+		gonzolog.Default().Error("Unable to apply log settings on reload", "error", err)
+	}
+	app.configReload.Publish()
+}
+
+// watchSIGHUP forces a config reload whenever the process receives
+// SIGHUP, mirroring the convention most long-running Unix daemons use for
+// "reload your config without restarting".
+func (app *App) watchSIGHUP() {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	for range sighup {
+		gonzolog.Default().Info("Received SIGHUP, reloading config")
+		if err := app.viper.ReadInConfig(); err != nil {
+			gonzolog.Default().Error("Unable to re-read config on SIGHUP", "error", err)
+			continue
+		}
+		app.reloadConfig()
+	}
+}
+
+// resolveSinkConfigs merges the repeatable --sink flag (shorthand
+// "type=target" pairs) with the richer `sinks:` config file section into
+// the list passed to sinks.NewDispatcher.
+func (app *App) resolveSinkConfigs() ([]sinks.Config, error) {
+	cfg := &app.cfg
+	out := make([]sinks.Config, 0, len(cfg.Sinks)+len(cfg.SinkConfigs))
+
+	for _, raw := range cfg.Sinks {
+		typ, target, ok := strings.Cut(raw, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --sink %q, expected 'type=target'", raw)
+		}
+		out = append(out, sinks.Config{Type: typ, Target: target})
+	}
+
+	for _, sc := range cfg.SinkConfigs {
+		out = append(out, sinks.Config{Type: sc.Type, Target: sc.Target, QueueSize: sc.QueueSize})
+	}
+
+	return out, nil
+}
+
+// applyProfile merges the named profile (--profile / GONZO_PROFILE) from
+// the config file's `profiles:` section over the base config, using a
+// dedicated viper.Viper instance so the merge doesn't disturb the
+// bindings on app.viper. It's a no-op if no profile was requested. CLI
+// flags still win last: MergeConfigMap only writes into the config-file
+// layer, which flags set on the command line and env vars sit above.
+func (app *App) applyProfile() error {
+	name := app.viper.GetString("profile")
+	if name == "" {
+		return nil
+	}
+
+	raw, ok := app.viper.Get("profiles").(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("profile %q requested but config has no 'profiles:' section", name)
+	}
+
+	merged, err := resolveProfile(raw, name, nil)
+	if err != nil {
+		return err
+	}
+
+	profileViper := viper.New()
+	if err := profileViper.MergeConfigMap(merged); err != nil {
+		return err
+	}
+
+	return app.viper.MergeConfigMap(profileViper.AllSettings())
+}
+
+// resolveProfile looks up name in profiles and flattens its `extends`
+// chain, with the base profile's settings applied first so the named
+// profile's own keys win. seen guards against extends cycles.
+func resolveProfile(profiles map[string]interface{}, name string, seen map[string]bool) (map[string]interface{}, error) {
+	if seen == nil {
+		seen = map[string]bool{}
+	}
+	if seen[name] {
+		return nil, fmt.Errorf("profile %q: circular 'extends' chain", name)
+	}
+	seen[name] = true
+
+	raw, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found", name)
+	}
+	profile, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("profile %q is not a mapping", name)
+	}
+
+	merged := map[string]interface{}{}
+	if base, ok := profile["extends"].(string); ok && base != "" {
+		baseMerged, err := resolveProfile(profiles, base, seen)
+		if err != nil {
+			return nil, err
+		}
+		merged = baseMerged
+	}
+
+	for k, v := range profile {
+		if k == "extends" {
+			continue
+		}
+		merged[k] = v
+	}
+
+	return merged, nil
+}
+
+// buildRunnables constructs the Runnables the current config enables:
+// the sink dispatcher (when any sink is configured), the Loki client
+// (when --loki-url is set), and the stop-words reload subscriber. The
+// OTLP listener, vmlogs client, file tailer, TUI, AI client, and format
+// registry are not built here.
+func (app *App) buildRunnables(ctx context.Context) ([]Runnable, error) {
+	var runnables []Runnable
+
+	// The dispatcher is constructed up front, rather than inside its own
+	// Runnable's Start, so that source runnables below can be handed a
+	// live *sinks.Dispatcher to forward decoded entries to.
+	var dispatcher *sinks.Dispatcher
+	sinkCfgs, err := app.resolveSinkConfigs()
+	if err != nil {
+		return nil, err
+	}
+	if len(sinkCfgs) > 0 {
+		d, err := sinks.NewDispatcher(ctx, sinkCfgs)
+		if err != nil {
+			return nil, err
+		}
+		dispatcher = d
+		runnables = append(runnables, newSinkRunnable(d))
+	}
+
+	if app.cfg.LokiURL != "" {
+		runnables = append(runnables, newLokiRunnable(&app.cfg, dispatcher))
+	}
+
+	app.stopWords = stopwords.NewSet(app.cfg.StopWords)
+	runnables = append(runnables, newStopWordsRunnable(app))
+
+	return runnables, nil
+}
+
+// runApp is the cobra RunE for the root command. It builds the
+// subsystems the resolved config calls for and runs a lifecycle manager
+// that starts them all against a shared context and stops them in
+// reverse order once that context is cancelled (on SIGINT/SIGTERM or a
+// subsystem failure).
+func (app *App) runApp(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	runnables, err := app.buildRunnables(ctx)
+	if err != nil {
+		return err
+	}
+
+	lc := newLifecycle(runnables)
+	return lc.run(ctx)
+}
+
+// lifecycle starts a set of Runnables against a shared context and stops
+// them in reverse order once the context is cancelled or any one of them
+// returns an error. A Runnable whose Start returns nil does not stop the
+// others.
+type lifecycle struct {
+	runnables []Runnable
+}
+
+func newLifecycle(runnables []Runnable) *lifecycle {
+	return &lifecycle{runnables: runnables}
+}
+
+func (lc *lifecycle) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// done[i] receives exactly once, when runnables[i].Start returns.
+	// Stop must never run before that: closing or tearing down state a
+	// still-running Start might concurrently touch (e.g. a channel it
+	// sends on) is a race.
+	done := make([]chan error, len(lc.runnables))
+	for i, r := range lc.runnables {
+		i, r := i, r
+		done[i] = make(chan error, 1)
+		go func() {
+			err := r.Start(ctx)
+			done[i] <- err
+			if err != nil {
+				// A runnable failing on its own tears down the rest,
+				// the same as an external SIGINT/SIGTERM would.
+				cancel()
+			}
+		}()
 	}
+
+	<-ctx.Done()
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer stopCancel()
+
+	var firstErr error
+	for i := len(lc.runnables) - 1; i >= 0; i-- {
+		select {
+		case err := <-done[i]:
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			if err := lc.runnables[i].Stop(stopCtx); err != nil {
+				gonzolog.Default().Error("Error stopping subsystem", "error", err)
+			}
+		case <-stopCtx.Done():
+			// Start(i) still hasn't returned, so calling Stop(i) now would
+			// race whatever it's still doing (e.g. a goroutine sending on
+			// a channel Stop is about to close). Skip it here and finish
+			// the stop in the background once Start(i) does return,
+			// rather than tearing down state it might still touch.
+			gonzolog.Default().Error("Timed out waiting for subsystem to stop, will stop it once it exits", "index", i)
+			i := i
+			go func() {
+				<-done[i]
+				if err := lc.runnables[i].Stop(context.Background()); err != nil {
+					gonzolog.Default().Error("Error stopping subsystem", "error", err)
+				}
+			}()
+		}
+	}
+
+	return firstErr
+}
+
+// lokiRunnable adapts loki.Client to the Runnable interface, forwarding
+// every decoded entry to dispatcher (if a --sink was configured) as it
+// arrives.
+type lokiRunnable struct {
+	client     *loki.Client
+	dispatcher *sinks.Dispatcher
+}
+
+func newLokiRunnable(cfg *Config, dispatcher *sinks.Dispatcher) *lokiRunnable {
+	client := loki.NewClient(loki.Config{
+		URL:      cfg.LokiURL,
+		User:     cfg.LokiUser,
+		Password: cfg.LokiPassword,
+		Token:    cfg.LokiToken,
+		Query:    cfg.LokiQuery,
+		Tail:     cfg.LokiTail,
+	})
+	return &lokiRunnable{client: client, dispatcher: dispatcher}
+}
+
+func (r *lokiRunnable) Start(ctx context.Context) error {
+	entries := make(chan logentry.Entry)
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for e := range entries {
+			if r.dispatcher != nil {
+				r.dispatcher.Dispatch([]logentry.Entry{e})
+			}
+		}
+	}()
+
+	// Stream only returns once it has stopped sending on entries, so
+	// it's safe to close the channel here - the goroutine above is the
+	// only other party and it just range-reads until closed.
+	err := r.client.Stream(ctx, entries)
+	close(entries)
+	<-drained
+	return err
+}
+
+func (r *lokiRunnable) Stop(ctx context.Context) error {
+	return nil
+}
+
+// sinkRunnable adapts an already-constructed sinks.Dispatcher to the
+// Runnable interface. The dispatcher is built eagerly in buildRunnables
+// (not here in Start) so that source runnables like lokiRunnable can be
+// handed a live dispatcher to forward entries to.
+type sinkRunnable struct {
+	dispatcher *sinks.Dispatcher
+}
+
+func newSinkRunnable(dispatcher *sinks.Dispatcher) *sinkRunnable {
+	return &sinkRunnable{dispatcher: dispatcher}
+}
+
+func (r *sinkRunnable) Start(ctx context.Context) error {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			for sink, stats := range r.dispatcher.Stats() {
+				if stats.Dropped > 0 {
+					gonzolog.Default().Warn("Sink is dropping entries", "sink", sink, "written", stats.Written, "dropped", stats.Dropped)
+				}
+			}
+		}
+	}
+}
+
+func (r *sinkRunnable) Stop(ctx context.Context) error {
+	return r.dispatcher.Close()
+}
+
+// stopWordsRunnable keeps app.stopWords in sync with --stop-words across
+// config reloads: the concrete subscriber that makes config.Broadcaster's
+// "swap state without restarting the TUI" promise real instead of
+// aspirational, since reloadConfig previously only ever re-applied the
+// profile and logger settings itself.
+type stopWordsRunnable struct {
+	app *App
+}
+
+func newStopWordsRunnable(app *App) *stopWordsRunnable {
+	return &stopWordsRunnable{app: app}
+}
+
+func (r *stopWordsRunnable) Start(ctx context.Context) error {
+	reload := r.app.configReload.Subscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-reload:
+			r.app.reloadMu.Lock()
+			words := r.app.cfg.StopWords
+			r.app.reloadMu.Unlock()
+			r.app.stopWords.Update(words)
+		}
+	}
+}
+
+func (r *stopWordsRunnable) Stop(ctx context.Context) error {
+	return nil
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	app := NewApp()
+	if err := app.rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
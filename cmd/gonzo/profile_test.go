@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestResolveProfile(t *testing.T) {
+	profiles := map[string]interface{}{
+		"base": map[string]interface{}{
+			"format": "json",
+			"skin":   "dracula",
+		},
+		"k8s-prod": map[string]interface{}{
+			"extends":        "base",
+			"skin":           "monokai",
+			"otlp-grpc-port": 4317,
+		},
+	}
+
+	merged, err := resolveProfile(profiles, "k8s-prod", nil)
+	if err != nil {
+		t.Fatalf("resolveProfile: %v", err)
+	}
+
+	if merged["format"] != "json" {
+		t.Errorf("format = %v, want inherited %q", merged["format"], "json")
+	}
+	if merged["skin"] != "monokai" {
+		t.Errorf("skin = %v, want override %q", merged["skin"], "monokai")
+	}
+	if merged["otlp-grpc-port"] != 4317 {
+		t.Errorf("otlp-grpc-port = %v, want 4317", merged["otlp-grpc-port"])
+	}
+	if _, ok := merged["extends"]; ok {
+		t.Errorf("merged profile should not carry the 'extends' key itself")
+	}
+}
+
+func TestResolveProfileUnknown(t *testing.T) {
+	profiles := map[string]interface{}{
+		"base": map[string]interface{}{"skin": "dracula"},
+	}
+
+	if _, err := resolveProfile(profiles, "missing", nil); err == nil {
+		t.Fatal("expected an error for an unknown profile name")
+	}
+}
+
+func TestResolveProfileCircularExtends(t *testing.T) {
+	profiles := map[string]interface{}{
+		"a": map[string]interface{}{"extends": "b"},
+		"b": map[string]interface{}{"extends": "a"},
+	}
+
+	if _, err := resolveProfile(profiles, "a", nil); err == nil {
+		t.Fatal("expected an error for a circular 'extends' chain")
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Mu-L/gonzo/internal/sinks"
+)
+
+func TestResolveSinkConfigs(t *testing.T) {
+	app := &App{cfg: Config{
+		Sinks: []string{"file=/tmp/gonzo-tap.json", "otlp-http=http://localhost:4318/v1/logs"},
+		SinkConfigs: []SinkConfig{
+			{Type: "loki-push", Target: "http://localhost:3100/loki/api/v1/push", QueueSize: 500},
+		},
+	}}
+
+	got, err := app.resolveSinkConfigs()
+	if err != nil {
+		t.Fatalf("resolveSinkConfigs: %v", err)
+	}
+
+	want := []sinks.Config{
+		{Type: "file", Target: "/tmp/gonzo-tap.json"},
+		{Type: "otlp-http", Target: "http://localhost:4318/v1/logs"},
+		{Type: "loki-push", Target: "http://localhost:3100/loki/api/v1/push", QueueSize: 500},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("resolveSinkConfigs returned %d configs, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("config %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestResolveSinkConfigsInvalid(t *testing.T) {
+	app := &App{cfg: Config{Sinks: []string{"not-a-valid-sink"}}}
+
+	if _, err := app.resolveSinkConfigs(); err == nil {
+		t.Fatal("expected an error for a --sink value without 'type=target'")
+	}
+}
@@ -0,0 +1,42 @@
+// Package config broadcasts configuration reload events so long-running
+// subsystems (the skin loader, stop-words analyzer, format registry, AI
+// model selector, log-buffer sizer, ...) can pick up changes made to the
+// config file or pushed via SIGHUP without restarting the TUI.
+package config
+
+import "sync"
+
+// Broadcaster fans a reload signal out to any number of subscribers. The
+// zero value is ready to use.
+type Broadcaster struct {
+	mu   sync.Mutex
+	subs []chan struct{}
+}
+
+// Subscribe returns a channel that receives a value every time Publish is
+// called. The channel is buffered so a slow or inactive subscriber never
+// blocks the broadcaster; only the most recent reload is guaranteed to be
+// observed.
+func (b *Broadcaster) Subscribe() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	b.subs = append(b.subs, ch)
+	return ch
+}
+
+// Publish notifies every current subscriber that the configuration has
+// changed. It never blocks: subscribers that haven't drained their
+// previous notification simply coalesce into the next one.
+func (b *Broadcaster) Publish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
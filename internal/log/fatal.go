@@ -0,0 +1,22 @@
+package log
+
+import "os"
+
+// Fatal logs msg at error level with args and then exits the process with
+// status 1. It replaces the stdlib log.Fatalf calls throughout gonzo so
+// that a fatal error on startup renders consistently with everything
+// else the logger prints.
+func Fatal(msg string, args ...any) {
+	Default().Error(msg, args...)
+	os.Exit(1)
+}
+
+// Recover should be deferred at the top of a goroutine that must not
+// silently die; it logs the panic value and re-panics so crash reporting
+// upstream of the caller (if any) still sees it.
+func Recover() {
+	if r := recover(); r != nil {
+		Default().Error("recovered panic", "panic", r)
+		panic(r)
+	}
+}
@@ -0,0 +1,139 @@
+// Package log is gonzo's project-wide logging facade. It wraps log/slog
+// with a pluggable handler: a colorized handler for interactive TTY use
+// and a JSON handler for piped stdout or machine consumption. Subsystems
+// that run alongside the TUI (the OTLP listener, the vmlogs and Loki
+// clients, file watchers, ...) should log through this package instead of
+// the stdlib "log" package so that crash diagnostics share one format.
+package log
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// Format selects the handler used to render log records.
+type Format string
+
+const (
+	// FormatAuto picks FormatTTY when stdout is a terminal and FormatJSON
+	// otherwise.
+	FormatAuto Format = "auto"
+	FormatTTY  Format = "tty"
+	FormatJSON Format = "json"
+)
+
+// Options configures the default logger.
+type Options struct {
+	Level  slog.Level
+	Format Format
+	Output io.Writer // defaults to os.Stderr
+
+	// File, if set, additionally mirrors every record to this path, one
+	// record per line, rendered by the same handler as Output: the file
+	// holds JSON only when Format resolves to FormatJSON, and colorized
+	// TTY lines otherwise.
+	File string
+}
+
+var (
+	// mu guards defaultLogger and currentLogFile below. Init is reachable
+	// concurrently from more than one reload path (SIGHUP and viper's
+	// fsnotify watcher both call it via App.reloadConfig), so swapping
+	// either var without a lock is a data race - go test -race catches it
+	// within the first few iterations of a concurrent Init.
+	mu sync.Mutex
+
+	defaultLogger = slog.New(newTTYHandler(os.Stderr, slog.LevelInfo))
+
+	// currentLogFile is the --log-file handle the active defaultLogger
+	// writes through, if any. Init closes it after swapping in a new one
+	// so repeated calls (e.g. on every config reload) don't leak a file
+	// descriptor per call.
+	currentLogFile *os.File
+)
+
+// Init configures the package-level default logger used by Default() and
+// the context helpers below. It can be called more than once - e.g. on
+// every config reload - to pick up new settings.
+func Init(opts Options) (*slog.Logger, error) {
+	output := opts.Output
+	if output == nil {
+		output = os.Stderr
+	}
+
+	var file *os.File
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		file = f
+		output = io.MultiWriter(output, f)
+	}
+
+	format := opts.Format
+	if format == "" || format == FormatAuto {
+		format = FormatJSON
+		if isTerminal(os.Stdout) {
+			format = FormatTTY
+		}
+	}
+
+	var handler slog.Handler
+	switch format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(output, &slog.HandlerOptions{Level: opts.Level})
+	default:
+		handler = newTTYHandler(output, opts.Level)
+	}
+
+	logger := slog.New(handler)
+
+	mu.Lock()
+	previous := currentLogFile
+	defaultLogger = logger
+	currentLogFile = file
+	mu.Unlock()
+
+	if previous != nil {
+		previous.Close()
+	}
+
+	return logger, nil
+}
+
+// Default returns the current package-level logger.
+func Default() *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return defaultLogger
+}
+
+type ctxKey struct{}
+
+// WithContext attaches logger to ctx so subsystems can pull it back out
+// with FromContext and attach their own structured fields, e.g.
+// source=otlp-grpc port=4317.
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the logger attached to ctx, or Default() if none
+// was attached.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return Default()
+}
+
+// ParseLevel parses the --log-level flag values (debug, info, warn,
+// error) into a slog.Level.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(s))
+	return level, err
+}
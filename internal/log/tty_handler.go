@@ -0,0 +1,99 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// ttyHandler renders records as a single colorized line, e.g.:
+//
+//	15:04:05 INF listener started source=otlp-grpc port=4317
+//
+// It's deliberately simple compared to the JSON handler: it's meant to be
+// read by a human watching the terminal, not parsed.
+type ttyHandler struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+}
+
+func newTTYHandler(out io.Writer, level slog.Leveler) *ttyHandler {
+	return &ttyHandler{mu: &sync.Mutex{}, out: out, level: level}
+}
+
+func (h *ttyHandler) Enabled(_ context.Context, level slog.Level) bool {
+	min := slog.LevelInfo
+	if h.level != nil {
+		min = h.level.Level()
+	}
+	return level >= min
+}
+
+func (h *ttyHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fmt.Fprintf(h.out, "%s %s %s", r.Time.Format(time.TimeOnly), levelTag(r.Level), r.Message)
+
+	for _, a := range h.attrs {
+		fmt.Fprintf(h.out, " %s=%v", a.Key, a.Value)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		fmt.Fprintf(h.out, " %s=%v", a.Key, a.Value)
+		return true
+	})
+	fmt.Fprintln(h.out)
+
+	return nil
+}
+
+func (h *ttyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &clone
+}
+
+func (h *ttyHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.groups = append(append([]string{}, h.groups...), name)
+	return &clone
+}
+
+const (
+	colorReset  = "\x1b[0m"
+	colorGray   = "\x1b[90m"
+	colorBlue   = "\x1b[34m"
+	colorYellow = "\x1b[33m"
+	colorRed    = "\x1b[31m"
+)
+
+func levelTag(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return colorRed + "ERR" + colorReset
+	case level >= slog.LevelWarn:
+		return colorYellow + "WRN" + colorReset
+	case level >= slog.LevelInfo:
+		return colorBlue + "INF" + colorReset
+	default:
+		return colorGray + "DBG" + colorReset
+	}
+}
+
+// isTerminal reports whether f looks like an interactive terminal. It's
+// intentionally conservative: anything it can't confirm is treated as
+// non-interactive, which is the safer default for --log-format=auto.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (info.Mode() & os.ModeCharDevice) != 0
+}
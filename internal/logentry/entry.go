@@ -0,0 +1,18 @@
+// Package logentry defines the common log record shape shared by every
+// ingestion source (OTLP, Victoria Logs, Loki, file tailers, ...) so the
+// rest of gonzo - filtering, severity buckets, the dashboard - only has to
+// deal with a single type.
+package logentry
+
+import "time"
+
+// Entry is a single log record normalized from its source-specific wire
+// format into OTLP-style fields.
+type Entry struct {
+	Timestamp  time.Time
+	Body       string
+	Severity   string
+	Source     string
+	Attributes map[string]string
+	Resource   map[string]string
+}
@@ -0,0 +1,223 @@
+// Package loki implements a streaming source for Grafana Loki, symmetric to
+// the Victoria Logs client: it backfills recent history with
+// /loki/api/v1/query_range and then, when tailing is enabled, follows new
+// lines over the /loki/api/v1/tail websocket endpoint. Every line is decoded
+// into a logentry.Entry with its Loki stream labels copied over as
+// attributes, so the existing filter/severity pipeline works unchanged.
+package loki
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/Mu-L/gonzo/internal/logentry"
+)
+
+// Config holds the connection details for a Loki instance.
+type Config struct {
+	URL      string
+	User     string
+	Password string
+	Token    string
+	Query    string
+	Tail     bool
+}
+
+// Client streams log entries from Loki.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// NewClient creates a Loki client for the given configuration.
+func NewClient(cfg Config) *Client {
+	return &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Stream backfills recent entries via query_range and, if Tail is set,
+// keeps following new entries via the tail websocket until ctx is
+// cancelled. Decoded entries are sent to out.
+func (c *Client) Stream(ctx context.Context, out chan<- logentry.Entry) error {
+	if err := c.backfill(ctx, out); err != nil {
+		return fmt.Errorf("loki: backfill: %w", err)
+	}
+
+	if !c.cfg.Tail {
+		return nil
+	}
+
+	return c.tail(ctx, out)
+}
+
+// backfill pulls recent history with a single query_range request.
+func (c *Client) backfill(ctx context.Context, out chan<- logentry.Entry) error {
+	u, err := url.Parse(strings.TrimRight(c.cfg.URL, "/") + "/loki/api/v1/query_range")
+	if err != nil {
+		return err
+	}
+
+	q := u.Query()
+	q.Set("query", c.cfg.Query)
+	q.Set("direction", "forward")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	c.authenticate(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, u.Redacted())
+	}
+
+	var payload queryRangeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("decode query_range response: %w", err)
+	}
+
+	for _, stream := range payload.Data.Result {
+		for _, entry := range decodeStream(stream) {
+			select {
+			case out <- entry:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+// tail follows new entries over the /loki/api/v1/tail websocket until ctx
+// is cancelled or the connection drops.
+func (c *Client) tail(ctx context.Context, out chan<- logentry.Entry) error {
+	u, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/loki/api/v1/tail"
+
+	q := u.Query()
+	q.Set("query", c.cfg.Query)
+	u.RawQuery = q.Encode()
+
+	header := http.Header{}
+	c.authenticateHeader(header)
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), header)
+	if err != nil {
+		return fmt.Errorf("dial tail websocket: %w", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	for {
+		var payload tailResponse
+		if err := conn.ReadJSON(&payload); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("read tail message: %w", err)
+		}
+
+		for _, stream := range payload.Streams {
+			for _, entry := range decodeStream(stream) {
+				select {
+				case out <- entry:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+	}
+}
+
+func (c *Client) authenticate(req *http.Request) {
+	c.authenticateHeader(req.Header)
+}
+
+func (c *Client) authenticateHeader(header http.Header) {
+	switch {
+	case c.cfg.Token != "":
+		header.Set("Authorization", "Bearer "+c.cfg.Token)
+	case c.cfg.User != "":
+		header.Set("Authorization", "Basic "+basicAuth(c.cfg.User, c.cfg.Password))
+	}
+}
+
+func basicAuth(user, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(user + ":" + password))
+}
+
+// streamResult is the `{stream: {...labels}, values: [[ts, line], ...]}`
+// shape shared by query_range and tail responses.
+type streamResult struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type queryRangeResponse struct {
+	Data struct {
+		Result []streamResult `json:"result"`
+	} `json:"data"`
+}
+
+type tailResponse struct {
+	Streams []streamResult `json:"streams"`
+}
+
+// decodeStream turns one Loki stream entry into log entries, copying its
+// labels over as OTLP-style attributes.
+func decodeStream(stream streamResult) []logentry.Entry {
+	entries := make([]logentry.Entry, 0, len(stream.Values))
+	for _, value := range stream.Values {
+		ts, body := value[0], value[1]
+		nanos, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		attributes := make(map[string]string, len(stream.Stream))
+		for k, v := range stream.Stream {
+			attributes[k] = v
+		}
+
+		entries = append(entries, logentry.Entry{
+			Timestamp:  time.Unix(0, nanos),
+			Body:       body,
+			Severity:   attributes["level"],
+			Source:     "loki",
+			Attributes: attributes,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,56 @@
+package loki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecodeStream(t *testing.T) {
+	stream := streamResult{
+		Stream: map[string]string{"app": "myapp", "level": "error"},
+		Values: [][2]string{
+			{"1700000000000000000", "first line"},
+			{"1700000001000000000", "second line"},
+		},
+	}
+
+	entries := decodeStream(stream)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+
+	first := entries[0]
+	if first.Body != "first line" {
+		t.Errorf("Body = %q, want %q", first.Body, "first line")
+	}
+	if !first.Timestamp.Equal(time.Unix(0, 1700000000000000000)) {
+		t.Errorf("Timestamp = %v, want %v", first.Timestamp, time.Unix(0, 1700000000000000000))
+	}
+	if first.Severity != "error" {
+		t.Errorf("Severity = %q, want %q (from the 'level' label)", first.Severity, "error")
+	}
+	if first.Source != "loki" {
+		t.Errorf("Source = %q, want %q", first.Source, "loki")
+	}
+	if first.Attributes["app"] != "myapp" {
+		t.Errorf("Attributes[app] = %q, want %q", first.Attributes["app"], "myapp")
+	}
+}
+
+func TestDecodeStreamSkipsUnparseableTimestamps(t *testing.T) {
+	stream := streamResult{
+		Stream: map[string]string{"app": "myapp"},
+		Values: [][2]string{
+			{"not-a-timestamp", "bad line"},
+			{"1700000000000000000", "good line"},
+		},
+	}
+
+	entries := decodeStream(stream)
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1 (the unparseable timestamp should be skipped)", len(entries))
+	}
+	if entries[0].Body != "good line" {
+		t.Errorf("Body = %q, want %q", entries[0].Body, "good line")
+	}
+}
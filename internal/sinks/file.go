@@ -0,0 +1,126 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/Mu-L/gonzo/internal/logentry"
+)
+
+// maxFileSinkBytes rotates a fileSink's output once the target file
+// reaches this size (bytes already in the file when the sink opened it
+// count towards it), keeping exactly one previous generation alongside it (the
+// target path with a ".1" suffix). This is a DoS/disk-fill guard for
+// long-running sessions tapped to a file, not a full logrotate
+// replacement - gonzo itself never reads the ".1" generation back.
+const maxFileSinkBytes = 100 << 20 // 100MiB
+
+// fileSink appends newline-delimited, OTLP-shaped JSON entries to a local
+// file, creating it if necessary and rotating it once it grows past
+// maxFileSinkBytes. It's the simplest sink: a durable record of
+// everything gonzo saw, independent of the TUI's in-memory buffer.
+type fileSink struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+}
+
+func newFileSink(path string) (Sink, error) {
+	f, info, err := openFileSinkTarget(path)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{path: path, file: f, size: info.Size()}, nil
+}
+
+func openFileSinkTarget(path string) (*os.File, os.FileInfo, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, info, nil
+}
+
+func (s *fileSink) Write(_ context.Context, entries []logentry.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range entries {
+		line, err := json.Marshal(newFileSinkRecord(e))
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+
+		n, err := s.file.Write(line)
+		if err != nil {
+			return err
+		}
+		s.size += int64(n)
+
+		if s.size >= maxFileSinkBytes {
+			if err := s.rotate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rotate closes the current file, moves it to path+".1" (replacing any
+// earlier ".1"), and opens a fresh file at path.
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil {
+		return err
+	}
+
+	f, info, err := openFileSinkTarget(s.path)
+	if err != nil {
+		return err
+	}
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// fileSinkRecord is the on-disk shape for logentry.Entry: a JSON
+// projection of OTLP's log record fields rather than Go's default
+// field-capitalized encoding, so the file sink's output matches what an
+// OTLP-JSON-aware downstream (e.g. vector, an OTel collector's file
+// receiver) expects.
+type fileSinkRecord struct {
+	TimeUnixNano int64             `json:"time_unix_nano"`
+	Body         string            `json:"body"`
+	SeverityText string            `json:"severity_text"`
+	Source       string            `json:"source"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Resource     map[string]string `json:"resource,omitempty"`
+}
+
+func newFileSinkRecord(e logentry.Entry) fileSinkRecord {
+	return fileSinkRecord{
+		TimeUnixNano: e.Timestamp.UnixNano(),
+		Body:         e.Body,
+		SeverityText: e.Severity,
+		Source:       e.Source,
+		Attributes:   e.Attributes,
+		Resource:     e.Resource,
+	}
+}
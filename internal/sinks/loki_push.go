@@ -0,0 +1,84 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Mu-L/gonzo/internal/logentry"
+)
+
+// lokiPushSink forwards entries to Loki's /loki/api/v1/push endpoint,
+// grouping them into one stream per distinct attribute set since Loki
+// requires entries within a stream to share the same labels.
+type lokiPushSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newLokiPushSink(target string) (Sink, error) {
+	return &lokiPushSink{
+		url:        target,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type lokiPushRequest struct {
+	Streams []lokiPushStream `json:"streams"`
+}
+
+type lokiPushStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (s *lokiPushSink) Write(ctx context.Context, entries []logentry.Entry) error {
+	streams := map[string]*lokiPushStream{}
+	for _, e := range entries {
+		key := fmt.Sprintf("%v", e.Attributes)
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiPushStream{Stream: e.Attributes}
+			streams[key] = stream
+		}
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(e.Timestamp.UnixNano(), 10),
+			e.Body,
+		})
+	}
+
+	req := lokiPushRequest{}
+	for _, stream := range streams {
+		req.Streams = append(req.Streams, *stream)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("loki-push sink: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *lokiPushSink) Close() error {
+	return nil
+}
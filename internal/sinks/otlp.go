@@ -0,0 +1,121 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	collectorlogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Mu-L/gonzo/internal/logentry"
+)
+
+// otlpGRPCSink forwards entries to an OTLP/gRPC logs collector, the same
+// protocol gonzo's own OTLP listener accepts - handy for relaying a tail
+// of one gonzo instance into another, or into a real collector.
+type otlpGRPCSink struct {
+	conn   *grpc.ClientConn
+	client collectorlogspb.LogsServiceClient
+}
+
+func newOTLPGRPCSink(target string) (Sink, error) {
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial otlp-grpc sink %q: %w", target, err)
+	}
+	return &otlpGRPCSink{conn: conn, client: collectorlogspb.NewLogsServiceClient(conn)}, nil
+}
+
+func (s *otlpGRPCSink) Write(ctx context.Context, entries []logentry.Entry) error {
+	_, err := s.client.Export(ctx, buildExportRequest(entries))
+	return err
+}
+
+func (s *otlpGRPCSink) Close() error {
+	return s.conn.Close()
+}
+
+// otlpHTTPSink forwards entries as a protobuf-encoded OTLP/HTTP export
+// request, per the collector's /v1/logs endpoint.
+type otlpHTTPSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newOTLPHTTPSink(target string) (Sink, error) {
+	return &otlpHTTPSink{
+		url:        target,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (s *otlpHTTPSink) Write(ctx context.Context, entries []logentry.Entry) error {
+	body, err := proto.Marshal(buildExportRequest(entries))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("otlp-http sink: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *otlpHTTPSink) Close() error {
+	return nil
+}
+
+// buildExportRequest groups all entries under a single empty
+// resource/scope. Only each entry's Attributes are forwarded;
+// logentry.Entry.Resource is not included in the request.
+func buildExportRequest(entries []logentry.Entry) *collectorlogspb.ExportLogsServiceRequest {
+	records := make([]*logspb.LogRecord, 0, len(entries))
+	for _, e := range entries {
+		attrs := make([]*commonpb.KeyValue, 0, len(e.Attributes))
+		for k, v := range e.Attributes {
+			attrs = append(attrs, &commonpb.KeyValue{
+				Key:   k,
+				Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}},
+			})
+		}
+
+		records = append(records, &logspb.LogRecord{
+			TimeUnixNano: uint64(e.Timestamp.UnixNano()),
+			Body:         &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: e.Body}},
+			SeverityText: e.Severity,
+			Attributes:   attrs,
+		})
+	}
+
+	return &collectorlogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{
+			{
+				Resource: &resourcepb.Resource{},
+				ScopeLogs: []*logspb.ScopeLogs{
+					{LogRecords: records},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,183 @@
+// Package sinks lets gonzo re-emit ingested/analyzed log entries to
+// downstream destinations while still rendering the TUI, turning it into
+// a lightweight tap/tee for log pipelines rather than just a viewer.
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Mu-L/gonzo/internal/logentry"
+)
+
+// Sink is a downstream destination that entries can be forwarded to.
+// Implementations must be safe for concurrent use by a single
+// Dispatcher.
+type Sink interface {
+	// Write forwards entries to the sink. It should return promptly;
+	// sinks that need to batch or retry should do so internally rather
+	// than blocking the dispatcher indefinitely.
+	Write(ctx context.Context, entries []logentry.Entry) error
+
+	// Close flushes any buffered data and releases the sink's resources.
+	Close() error
+}
+
+// Config describes one configured sink, as parsed from a `--sink` flag
+// value or a `sinks:` config entry.
+type Config struct {
+	Type string // file, otlp-grpc, otlp-http, loki-push, vmlogs-push
+
+	// Target is the sink-specific destination: a file path for "file",
+	// or a URL for the network sinks.
+	Target string
+
+	// QueueSize bounds how many pending batches (one per Dispatch call)
+	// the dispatcher buffers for this sink before dropping. Zero selects
+	// DefaultQueueSize.
+	QueueSize int
+}
+
+// DefaultQueueSize is used when a Config doesn't specify QueueSize.
+const DefaultQueueSize = 1000
+
+// Stats reports how a single sink in the dispatcher is keeping up.
+type Stats struct {
+	Written int64
+	Dropped int64
+}
+
+// New constructs the Sink described by cfg.
+func New(cfg Config) (Sink, error) {
+	switch cfg.Type {
+	case "file":
+		return newFileSink(cfg.Target)
+	case "otlp-grpc":
+		return newOTLPGRPCSink(cfg.Target)
+	case "otlp-http":
+		return newOTLPHTTPSink(cfg.Target)
+	case "loki-push":
+		return newLokiPushSink(cfg.Target)
+	case "vmlogs-push":
+		return newVmlogsPushSink(cfg.Target)
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+}
+
+// entry pairs a sink with the queue and stats the Dispatcher maintains
+// for it. written/dropped are atomic since Dispatch and run() touch them
+// from different goroutines on every batch.
+type entry struct {
+	key     string // cfg.Type+"="+cfg.Target, unique per configured sink
+	sink    Sink
+	queue   chan []logentry.Entry
+	written atomic.Int64
+	dropped atomic.Int64
+}
+
+// Dispatcher fans entries out to a set of sinks concurrently. A slow or
+// stuck sink never blocks the others, or the pipeline feeding the
+// dispatcher: batches queued beyond a sink's QueueSize are dropped and
+// their entries counted rather than backing up.
+type Dispatcher struct {
+	mu      sync.RWMutex
+	entries []*entry
+	wg      sync.WaitGroup
+}
+
+// NewDispatcher builds sinks from cfgs and starts a forwarding goroutine
+// for each.
+func NewDispatcher(ctx context.Context, cfgs []Config) (*Dispatcher, error) {
+	d := &Dispatcher{}
+
+	for _, cfg := range cfgs {
+		sink, err := New(cfg)
+		if err != nil {
+			d.Close()
+			return nil, fmt.Errorf("sink %q: %w", cfg.Type, err)
+		}
+
+		size := cfg.QueueSize
+		if size <= 0 {
+			size = DefaultQueueSize
+		}
+
+		e := &entry{key: cfg.Type + "=" + cfg.Target, sink: sink, queue: make(chan []logentry.Entry, size)}
+		d.entries = append(d.entries, e)
+
+		d.wg.Add(1)
+		go d.run(ctx, e)
+	}
+
+	return d, nil
+}
+
+func (d *Dispatcher) run(ctx context.Context, e *entry) {
+	defer d.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case batch, ok := <-e.queue:
+			if !ok {
+				return
+			}
+			if err := e.sink.Write(ctx, batch); err != nil {
+				continue
+			}
+			e.written.Add(int64(len(batch)))
+		}
+	}
+}
+
+// Dispatch enqueues batch for every configured sink. A sink whose queue
+// is full drops the batch and increments its Dropped counter instead of
+// blocking the caller.
+func (d *Dispatcher) Dispatch(batch []logentry.Entry) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, e := range d.entries {
+		select {
+		case e.queue <- batch:
+		default:
+			e.dropped.Add(int64(len(batch)))
+		}
+	}
+}
+
+// Stats returns a snapshot of each sink's write/drop counters, suitable
+// for surfacing in the TUI status bar.
+func (d *Dispatcher) Stats() map[string]Stats {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	out := make(map[string]Stats, len(d.entries))
+	for _, e := range d.entries {
+		out[e.key] = Stats{Written: e.written.Load(), Dropped: e.dropped.Load()}
+	}
+	return out
+}
+
+// Close stops every sink's forwarding goroutine and closes the sinks
+// themselves.
+func (d *Dispatcher) Close() error {
+	d.mu.Lock()
+	for _, e := range d.entries {
+		close(e.queue)
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+
+	var firstErr error
+	for _, e := range d.entries {
+		if err := e.sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
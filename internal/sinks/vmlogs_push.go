@@ -0,0 +1,65 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Mu-L/gonzo/internal/logentry"
+)
+
+// vmlogsPushSink forwards entries to Victoria Logs' JSON line ingestion
+// endpoint (/insert/jsonline), one JSON object per entry separated by
+// newlines.
+type vmlogsPushSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newVmlogsPushSink(target string) (Sink, error) {
+	return &vmlogsPushSink{
+		url:        target,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (s *vmlogsPushSink) Write(ctx context.Context, entries []logentry.Entry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		line := map[string]any{
+			"_time": e.Timestamp.Format(time.RFC3339Nano),
+			"_msg":  e.Body,
+		}
+		for k, v := range e.Attributes {
+			line[k] = v
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/stream+json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("vmlogs-push sink: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *vmlogsPushSink) Close() error {
+	return nil
+}
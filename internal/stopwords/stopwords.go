@@ -0,0 +1,43 @@
+// Package stopwords tracks the user-configurable --stop-words list used
+// to filter noise out of log analysis, on top of the analyzer's built-in
+// base list. It's kept as its own small package, rather than a plain
+// []string on Config, so it can be swapped live on a config reload
+// without restarting the TUI.
+package stopwords
+
+import "sync"
+
+// Set holds the current additional stop words. It's safe for concurrent
+// reads (from the analyzer) and writes (from a config reload).
+type Set struct {
+	mu    sync.RWMutex
+	words map[string]struct{}
+}
+
+// NewSet builds a Set containing words.
+func NewSet(words []string) *Set {
+	s := &Set{}
+	s.Update(words)
+	return s
+}
+
+// Update atomically replaces the set's contents with words.
+func (s *Set) Update(words []string) {
+	m := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		m[w] = struct{}{}
+	}
+
+	s.mu.Lock()
+	s.words = m
+	s.mu.Unlock()
+}
+
+// Contains reports whether word is in the set.
+func (s *Set) Contains(word string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.words[word]
+	return ok
+}